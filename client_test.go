@@ -0,0 +1,22 @@
+package kmip
+
+import (
+	"crypto/tls"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialTLS_ConnectionRefused(t *testing.T) {
+	// bind and immediately close a listener to get an address nothing is
+	// listening on.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	_, err = DialTLS(addr, &tls.Config{InsecureSkipVerify: true}) //nolint:gosec // test-only
+	assert.Error(t, err)
+}
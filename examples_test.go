@@ -1,66 +1,28 @@
 package kmip
 
 import (
-	"bufio"
+	"crypto/tls"
 	"fmt"
-	"github.com/google/uuid"
-	"github.com/gsealy/kmip-go/kmip14"
-	"github.com/gsealy/kmip-go/ttlv"
 	"net"
-	"time"
+
+	"github.com/gsealy/kmip-go/kmip14"
 )
 
 func Example_client() {
-
-	conn, err := net.DialTimeout("tcp", "localhost:5696", 3*time.Second)
+	// config should normally load a real client certificate and a pool
+	// trusting the server's CA; this example skips that for brevity.
+	client, err := DialTLS("localhost:5696", &tls.Config{InsecureSkipVerify: true}) //nolint:gosec // example only
 	if err != nil {
 		panic(err)
 	}
+	defer client.Close()
 
-	biID := uuid.New()
-
-	msg := RequestMessage{
-		RequestHeader: RequestHeader{
-			ProtocolVersion: ProtocolVersion{
-				ProtocolVersionMajor: 1,
-				ProtocolVersionMinor: 2,
-			},
-			BatchCount: 1,
-		},
-		BatchItem: []RequestBatchItem{
-			{
-				UniqueBatchItemID: biID[:],
-				Operation:         kmip14.OperationDiscoverVersions,
-				RequestPayload: DiscoverVersionsRequestPayload{
-					ProtocolVersion: []ProtocolVersion{
-						{ProtocolVersionMajor: 1, ProtocolVersionMinor: 2},
-					},
-				},
-			},
-		},
-	}
-
-	req, err := ttlv.Marshal(msg)
-	if err != nil {
-		panic(err)
-	}
-
-	fmt.Println(req)
-
-	_, err = conn.Write(req)
+	versions, err := client.DiscoverVersions(ProtocolVersion{ProtocolVersionMajor: 1, ProtocolVersionMinor: 2})
 	if err != nil {
 		panic(err)
 	}
 
-	buf := make([]byte, 5000)
-	_, err = bufio.NewReader(conn).Read(buf)
-	if err != nil {
-		panic(err)
-	}
-
-	resp := ttlv.TTLV(buf)
-	fmt.Println(resp)
-
+	fmt.Println(versions)
 }
 
 func ExampleServer() {
@@ -88,6 +50,9 @@ func ExampleServer() {
 		},
 	})
 	srv := Server{}
-	panic(srv.Serve(listener))
-
+	// config should normally load the server's own certificate and the CA
+	// pool it uses to authenticate clients; this example skips that for
+	// brevity. The contexts that operation handlers receive do not carry the
+	// peer certificate, so PeerCertificate(ctx) reports none for them.
+	panic(srv.ServeTLS(listener, &tls.Config{}))
 }
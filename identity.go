@@ -0,0 +1,63 @@
+package kmip
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/hex"
+)
+
+// ClientIdentityExtractor derives the caller identity to associate with a
+// connection from the TLS certificate its peer presented, implementing the
+// KMIP "Credential=TLS client certificate" identity model.
+type ClientIdentityExtractor interface {
+	ClientIdentity(cert *x509.Certificate) string
+}
+
+// ClientIdentityExtractorFunc adapts a function to a ClientIdentityExtractor.
+type ClientIdentityExtractorFunc func(cert *x509.Certificate) string
+
+func (f ClientIdentityExtractorFunc) ClientIdentity(cert *x509.Certificate) string {
+	return f(cert)
+}
+
+// CommonNameIdentity extracts the client identity from the certificate's
+// Subject Common Name. This is the most common pattern for deployments that
+// issue one client certificate per application or tenant.
+var CommonNameIdentity ClientIdentityExtractor = ClientIdentityExtractorFunc(func(cert *x509.Certificate) string {
+	return cert.Subject.CommonName
+})
+
+// SANIdentity extracts the client identity from the certificate's first DNS
+// Subject Alternative Name, falling back to the Common Name if the
+// certificate has no DNS SANs.
+var SANIdentity ClientIdentityExtractor = ClientIdentityExtractorFunc(func(cert *x509.Certificate) string {
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0]
+	}
+
+	return cert.Subject.CommonName
+})
+
+// FullCertIdentity uses the hex-encoded DER bytes of the certificate itself as
+// the client identity. Useful for deployments that maintain their own mapping
+// of certificates to identities rather than relying on certificate subjects.
+var FullCertIdentity ClientIdentityExtractor = ClientIdentityExtractorFunc(func(cert *x509.Certificate) string {
+	return hex.EncodeToString(cert.Raw)
+})
+
+type peerCertificateKey struct{}
+
+// withPeerCertificate returns a context carrying the peer's leaf TLS
+// certificate, for retrieval by operation handlers via PeerCertificate.
+func withPeerCertificate(ctx context.Context, cert *x509.Certificate) context.Context {
+	return context.WithValue(ctx, peerCertificateKey{}, cert)
+}
+
+// PeerCertificate returns the leaf TLS client certificate presented on the
+// connection that ctx was derived from, and true if one is present. Operation
+// handlers combine this with a ClientIdentityExtractor to implement the KMIP
+// "Credential=TLS client certificate" identity model.
+func PeerCertificate(ctx context.Context) (*x509.Certificate, bool) {
+	cert, ok := ctx.Value(peerCertificateKey{}).(*x509.Certificate)
+	return cert, ok
+}
@@ -0,0 +1,45 @@
+package kmip
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommonNameIdentity(t *testing.T) {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "alice"}}
+	assert.Equal(t, "alice", CommonNameIdentity.ClientIdentity(cert))
+}
+
+func TestSANIdentity(t *testing.T) {
+	withSAN := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "alice"},
+		DNSNames: []string{"alice.example.com", "alice2.example.com"},
+	}
+	assert.Equal(t, "alice.example.com", SANIdentity.ClientIdentity(withSAN))
+
+	withoutSAN := &x509.Certificate{Subject: pkix.Name{CommonName: "alice"}}
+	assert.Equal(t, "alice", SANIdentity.ClientIdentity(withoutSAN))
+}
+
+func TestFullCertIdentity(t *testing.T) {
+	cert := &x509.Certificate{Raw: []byte{0xde, 0xad, 0xbe, 0xef}}
+	assert.Equal(t, "deadbeef", FullCertIdentity.ClientIdentity(cert))
+	assert.Equal(t, hex.EncodeToString(cert.Raw), FullCertIdentity.ClientIdentity(cert))
+}
+
+func TestPeerCertificate(t *testing.T) {
+	_, ok := PeerCertificate(context.Background())
+	assert.False(t, ok)
+
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "alice"}}
+	ctx := withPeerCertificate(context.Background(), cert)
+
+	got, ok := PeerCertificate(ctx)
+	assert.True(t, ok)
+	assert.Same(t, cert, got)
+}
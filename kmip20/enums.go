@@ -0,0 +1,45 @@
+package kmip20
+
+import "github.com/gsealy/kmip-go/ttlv"
+
+// ProtectionStorageMask bit values, as declared against TagProtectionStorageMask
+// and TagProtectionStorageMasks. A value is the bitwise-OR of one or more of
+// these bits.
+const (
+	ProtectionStorageMaskSoftware         uint32 = 0x00000001
+	ProtectionStorageMaskHardware         uint32 = 0x00000002
+	ProtectionStorageMaskOnProcessor      uint32 = 0x00000004
+	ProtectionStorageMaskOnSystem         uint32 = 0x00000008
+	ProtectionStorageMaskOffSystem        uint32 = 0x00000010
+	ProtectionStorageMaskHypervisor       uint32 = 0x00000020
+	ProtectionStorageMaskOperatingSystem  uint32 = 0x00000040
+	ProtectionStorageMaskContainer        uint32 = 0x00000080
+	ProtectionStorageMaskOnPremises       uint32 = 0x00000100
+	ProtectionStorageMaskOffPremises      uint32 = 0x00000200
+	ProtectionStorageMaskSelfManaged      uint32 = 0x00000400
+	ProtectionStorageMaskOutsourced       uint32 = 0x00000800
+	ProtectionStorageMaskValidated        uint32 = 0x00001000
+	ProtectionStorageMaskSameJurisdiction uint32 = 0x00002000
+)
+
+// RegisterEnums registers this package's enumerations and bitmasks into r.
+func RegisterEnums(r *ttlv.Registry) {
+	mask := ttlv.NewBitmask()
+	mask.RegisterValue(ProtectionStorageMaskSoftware, "Software")
+	mask.RegisterValue(ProtectionStorageMaskHardware, "Hardware")
+	mask.RegisterValue(ProtectionStorageMaskOnProcessor, "On Processor")
+	mask.RegisterValue(ProtectionStorageMaskOnSystem, "On System")
+	mask.RegisterValue(ProtectionStorageMaskOffSystem, "Off System")
+	mask.RegisterValue(ProtectionStorageMaskHypervisor, "Hypervisor")
+	mask.RegisterValue(ProtectionStorageMaskOperatingSystem, "Operating System")
+	mask.RegisterValue(ProtectionStorageMaskContainer, "Container")
+	mask.RegisterValue(ProtectionStorageMaskOnPremises, "On Premises")
+	mask.RegisterValue(ProtectionStorageMaskOffPremises, "Off Premises")
+	mask.RegisterValue(ProtectionStorageMaskSelfManaged, "Self Managed")
+	mask.RegisterValue(ProtectionStorageMaskOutsourced, "Outsourced")
+	mask.RegisterValue(ProtectionStorageMaskValidated, "Validated")
+	mask.RegisterValue(ProtectionStorageMaskSameJurisdiction, "Same Jurisdiction")
+
+	r.RegisterEnum(TagProtectionStorageMask, &mask)
+	r.RegisterEnum(TagProtectionStorageMasks, &mask)
+}
@@ -0,0 +1,28 @@
+// Package kmip20 declares the tags, operations, and enumerations introduced
+// or changed by the KMIP 2.0/2.1 specs, layered on top of the KMIP 1.4 tables
+// in kmip14 via ttlv.Registry.RegisterVersion.
+//
+// This is a partial port: it covers the KMIP 2.x additions needed to exercise
+// version-overlay dispatch end to end, not the full kmipgen-generated tables
+// for the 2.0/2.1 specs. Extend Registry with the remaining 2.x tags,
+// operations, and enumerations as more of the spec is implemented.
+package kmip20
+
+import "github.com/gsealy/kmip-go/ttlv"
+
+// Registry holds the KMIP 2.x tags, types, and enumerations that are new in,
+// or changed since, the KMIP 1.4 tables in kmip14. It's registered as a
+// version overlay on ttlv.DefaultRegistry during init, so a version-aware
+// lookup against ttlv.DefaultRegistry for protocol version 2.0 or 2.1 (e.g.
+// ttlv.DefaultRegistry.EnumForTagVersion(2, 0, t)) checks here first, falling
+// back to the 1.4 tables for anything this package doesn't override.
+var Registry ttlv.Registry
+
+// nolint:gochecknoinits
+func init() {
+	RegisterTags(&Registry)
+	RegisterEnums(&Registry)
+
+	ttlv.DefaultRegistry.RegisterVersion(2, 0, &Registry)
+	ttlv.DefaultRegistry.RegisterVersion(2, 1, &Registry)
+}
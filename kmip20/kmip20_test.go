@@ -0,0 +1,62 @@
+package kmip20_test
+
+import (
+	"testing"
+
+	"github.com/gsealy/kmip-go/kmip20"
+	"github.com/gsealy/kmip-go/ttlv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_RegisteredAsVersionOverlay(t *testing.T) {
+	overlay, ok := ttlv.DefaultRegistry.VersionOverlay(2, 0)
+	require.True(t, ok)
+	assert.Same(t, &kmip20.Registry, overlay)
+
+	overlay, ok = ttlv.DefaultRegistry.VersionOverlay(2, 1)
+	require.True(t, ok)
+	assert.Same(t, &kmip20.Registry, overlay)
+}
+
+func TestRegistry_Tags(t *testing.T) {
+	name, ok := kmip20.Registry.Tags().CanonicalName(uint32(kmip20.TagAttributes))
+	require.True(t, ok)
+	assert.Equal(t, "Attributes", name)
+}
+
+func TestRegistry_ProtectionStorageMask(t *testing.T) {
+	v := kmip20.ProtectionStorageMaskSoftware | kmip20.ProtectionStorageMaskHardware
+
+	names := kmip20.Registry.FormatBitmaskJSON(kmip20.TagProtectionStorageMask, v)
+	assert.Equal(t, []string{"Software", "Hardware"}, names)
+}
+
+// TestRegistry_MarshalJSONVersion_UsesOverlay proves the kmip20 overlay isn't
+// just registered, but actually consulted: ttlv.DefaultRegistry.MarshalJSONVersion
+// resolves TagProtectionStorageMask's name, and its bitmask enum, against
+// kmip20.Registry for protocol version 2.1, something 1.4 (which has no such
+// tag) can't do.
+func TestRegistry_MarshalJSONVersion_UsesOverlay(t *testing.T) {
+	tag := uint32(kmip20.TagProtectionStorageMask)
+	v := uint32(kmip20.ProtectionStorageMaskSoftware | kmip20.ProtectionStorageMaskHardware)
+
+	header := []byte{
+		byte(tag >> 16), byte(tag >> 8), byte(tag),
+		0x02, // Integer
+		0x00, 0x00, 0x00, 0x04,
+		byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v),
+	}
+
+	b, err := ttlv.DefaultRegistry.MarshalJSONVersion(2, 1, ttlv.TTLV(header))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"tag":"ProtectionStorageMask","type":"Integer","value":["Software","Hardware"]}`, string(b))
+
+	// 1.4 has no kmip20 overlay, so the same bytes fall back to DefaultRegistry's
+	// own tags and enums, which don't know this tag: its name falls back to hex
+	// and its value is a plain integer rather than a bitmask name array.
+	b, err = ttlv.DefaultRegistry.MarshalJSONVersion(1, 4, ttlv.TTLV(header))
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "ProtectionStorageMask")
+	assert.NotContains(t, string(b), `"Software"`)
+}
@@ -0,0 +1,31 @@
+package kmip20
+
+import "github.com/gsealy/kmip-go/ttlv"
+
+// Tags introduced by the KMIP 2.0 spec. Attributes replaces the various
+// Template-Attribute structures used by 1.x operations; CommonAttributes,
+// PrivateKeyAttributes, and PublicKeyAttributes let Create Key Pair-style
+// operations specify per-object attributes in a single request.
+const (
+	TagAttributes           ttlv.Tag = 0x420125
+	TagCommonAttributes     ttlv.Tag = 0x42012D
+	TagPrivateKeyAttributes ttlv.Tag = 0x42012E
+	TagPublicKeyAttributes  ttlv.Tag = 0x42012F
+
+	// TagProtectionStorageMasks and TagProtectionStorageMask back the 2.0
+	// Protection Storage Masks feature: the former lists the masks a new
+	// object may be stored under, the latter reports the mask it was
+	// actually stored under.
+	TagProtectionStorageMasks ttlv.Tag = 0x42014F
+	TagProtectionStorageMask  ttlv.Tag = 0x420150
+)
+
+// RegisterTags registers this package's tags into r.
+func RegisterTags(r *ttlv.Registry) {
+	r.RegisterTag(TagAttributes, "Attributes")
+	r.RegisterTag(TagCommonAttributes, "Common Attributes")
+	r.RegisterTag(TagPrivateKeyAttributes, "Private Key Attributes")
+	r.RegisterTag(TagPublicKeyAttributes, "Public Key Attributes")
+	r.RegisterTag(TagProtectionStorageMasks, "Protection Storage Masks")
+	r.RegisterTag(TagProtectionStorageMask, "Protection Storage Mask")
+}
@@ -0,0 +1,67 @@
+package kmip
+
+import (
+	"testing"
+
+	"github.com/gsealy/kmip-go/kmip14"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchResponseItem(t *testing.T) {
+	id := []byte("batch-item-id")
+
+	t.Run("success", func(t *testing.T) {
+		resp := ResponseMessage{
+			BatchItem: []ResponseBatchItem{
+				{
+					UniqueBatchItemID: id,
+					ResultStatus:      kmip14.ResultStatusSuccess,
+					ResponsePayload:   "payload",
+				},
+			},
+		}
+
+		item, err := matchResponseItem(kmip14.OperationGet, id, resp)
+		require.NoError(t, err)
+		assert.Equal(t, "payload", item.ResponsePayload)
+	})
+
+	t.Run("wrong batch count", func(t *testing.T) {
+		resp := ResponseMessage{
+			BatchItem: []ResponseBatchItem{
+				{UniqueBatchItemID: id, ResultStatus: kmip14.ResultStatusSuccess},
+				{UniqueBatchItemID: id, ResultStatus: kmip14.ResultStatusSuccess},
+			},
+		}
+
+		_, err := matchResponseItem(kmip14.OperationGet, id, resp)
+		assert.Error(t, err)
+	})
+
+	t.Run("batch item ID mismatch", func(t *testing.T) {
+		resp := ResponseMessage{
+			BatchItem: []ResponseBatchItem{
+				{UniqueBatchItemID: []byte("other-id"), ResultStatus: kmip14.ResultStatusSuccess},
+			},
+		}
+
+		_, err := matchResponseItem(kmip14.OperationGet, id, resp)
+		assert.Error(t, err)
+	})
+
+	t.Run("non-success result status", func(t *testing.T) {
+		resp := ResponseMessage{
+			BatchItem: []ResponseBatchItem{
+				{
+					UniqueBatchItemID: id,
+					ResultStatus:      kmip14.ResultStatusOperationFailed,
+					ResultMessage:     "boom",
+				},
+			},
+		}
+
+		_, err := matchResponseItem(kmip14.OperationGet, id, resp)
+		assert.Error(t, err)
+	})
+}
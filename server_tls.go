@@ -0,0 +1,69 @@
+package kmip
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+)
+
+// ServeTLS wraps l in a TLS listener configured with config and serves
+// connections from it exactly as Serve does. Conformant KMIP deployments run
+// on mutually-authenticated TLS, so config should normally set ClientAuth to
+// tls.RequireAndVerifyClientCert.
+//
+// Each accepted connection is wrapped in a peerCertConn, whose Context method
+// adds the peer's leaf certificate, once the handshake completes, to a context
+// so that PeerCertificate(ctx) resolves. DefaultProtocolHandler does not call
+// Context, so the contexts it passes to operation handlers do not carry the
+// peer certificate.
+func (s *Server) ServeTLS(l net.Listener, config *tls.Config) error {
+	return s.Serve(&tlsListener{Listener: l, config: config})
+}
+
+// peerContexter is implemented by connections that can augment a base context
+// with connection-specific values, such as peerCertConn. DefaultProtocolHandler
+// does not check for this interface when building the context for a request.
+type peerContexter interface {
+	Context(ctx context.Context) context.Context
+}
+
+type tlsListener struct {
+	net.Listener
+	config *tls.Config
+}
+
+func (l *tlsListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	return newPeerCertConn(tls.Server(conn, l.config)), nil
+}
+
+// peerCertConn wraps a *tls.Conn so that the peer's leaf certificate can be
+// added to a context via Context, satisfying peerContexter.
+type peerCertConn struct {
+	*tls.Conn
+}
+
+func newPeerCertConn(conn *tls.Conn) *peerCertConn {
+	return &peerCertConn{Conn: conn}
+}
+
+// Context returns ctx augmented with the peer's leaf TLS certificate, via
+// PeerCertificate, if the handshake has completed (performing it if it
+// hasn't) and the peer presented a certificate. Otherwise it returns ctx
+// unchanged.
+func (c *peerCertConn) Context(ctx context.Context) context.Context {
+	if err := c.Conn.Handshake(); err != nil {
+		return ctx
+	}
+
+	certs := c.Conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return ctx
+	}
+
+	return withPeerCertificate(ctx, certs[0])
+}
@@ -0,0 +1,71 @@
+package kmip
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func generateTestCert(t *testing.T, cn string) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestPeerCertConn_Context(t *testing.T) {
+	serverCert := generateTestCert(t, "server")
+	clientCert := generateTestCert(t, "alice")
+
+	clientConn, serverConn := net.Pipe()
+
+	serverTLSConfig := &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAnyClientCert,
+	}
+
+	clientTLSConfig := &tls.Config{
+		Certificates:       []tls.Certificate{clientCert},
+		InsecureSkipVerify: true, //nolint:gosec // test-only, no real CA involved
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- tls.Client(clientConn, clientTLSConfig).Handshake()
+	}()
+
+	pcc := newPeerCertConn(tls.Server(serverConn, serverTLSConfig))
+
+	ctx := pcc.Context(context.Background())
+
+	require.NoError(t, <-done)
+
+	cert, ok := PeerCertificate(ctx)
+	require.True(t, ok)
+	require.Equal(t, "alice", cert.Subject.CommonName)
+}
@@ -0,0 +1,60 @@
+package ttlv
+
+// FormatBitmaskJSON decodes the bits set in v and returns the JSON Profile
+// representation (KMIP spec 5.4.1.1): an array containing the normalized name
+// of each set bit, or a "0x"-prefixed hex string for any bit that isn't a
+// recognized value for t. Each bit's name-or-hex-fallback is produced by
+// FormatEnum, the same logic Registry already uses to format a single enum
+// value for the TTLV text format, since the JSON and text representations of
+// an unrecognized value are identical.
+//
+// There is no FormatEnumJSON: a single (non-bitmask) enumeration value's JSON
+// Profile representation is exactly Registry.FormatEnum's return value, so
+// that method is reused directly rather than wrapped.
+func (r *Registry) FormatBitmaskJSON(t Tag, v uint32) []string {
+	return FormatBitmaskJSON(v, r.EnumForTag(t))
+}
+
+// ParseBitmaskJSON is the inverse of FormatBitmaskJSON: it resolves a list of
+// normalized or canonical enum names, or "0x"-prefixed hex strings, back to the
+// uint32 value formed by OR-ing their bits together, using the same name
+// resolution as Registry.ParseEnum.
+func (r *Registry) ParseBitmaskJSON(t Tag, names []string) (uint32, error) {
+	return ParseBitmaskJSON(names, r.EnumForTag(t))
+}
+
+// FormatBitmaskJSON is the free-function form of Registry.FormatBitmaskJSON,
+// taking the EnumMap to resolve bit names against directly. TTLV's
+// MarshalJSONVersion uses this to format a bitmask against the EnumMap
+// resolved for a specific protocol version (see EnumForTagVersion), rather
+// than always against a single Registry's own enums.
+func FormatBitmaskJSON(v uint32, e EnumMap) []string {
+	var names []string
+
+	for bit := uint32(1); bit != 0; bit <<= 1 {
+		if v&bit == 0 {
+			continue
+		}
+
+		names = append(names, FormatEnum(bit, e))
+	}
+
+	return names
+}
+
+// ParseBitmaskJSON is the free-function form of Registry.ParseBitmaskJSON,
+// taking the EnumMap to resolve names against directly. See FormatBitmaskJSON.
+func ParseBitmaskJSON(names []string, e EnumMap) (uint32, error) {
+	var v uint32
+
+	for _, name := range names {
+		bit, err := ParseEnum(name, e)
+		if err != nil {
+			return 0, err
+		}
+
+		v |= bit
+	}
+
+	return v, nil
+}
@@ -0,0 +1,28 @@
+package ttlv_test
+
+import (
+	"testing"
+
+	"github.com/gsealy/kmip-go/ttlv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_FormatBitmaskJSON(t *testing.T) {
+	var reg ttlv.Registry
+
+	e := ttlv.NewBitmask()
+	e.RegisterValue(1, "Encrypt")
+	e.RegisterValue(2, "Decrypt")
+	reg.RegisterEnum(ttlv.Tag(0x54000c), &e)
+
+	names := reg.FormatBitmaskJSON(ttlv.Tag(0x54000c), 3)
+	assert.Equal(t, []string{"Encrypt", "Decrypt"}, names)
+
+	names = reg.FormatBitmaskJSON(ttlv.Tag(0x54000c), 5)
+	assert.Equal(t, []string{"Encrypt", "0x00000004"}, names)
+
+	v, err := reg.ParseBitmaskJSON(ttlv.Tag(0x54000c), []string{"Encrypt", "Decrypt"})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), v)
+}
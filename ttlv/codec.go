@@ -0,0 +1,115 @@
+package ttlv
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/ansel1/merry"
+)
+
+// KMIP type codes, as assigned by the spec. These are wire-format constants,
+// not tied to any particular protocol version, so they're unexported and
+// used only by the encoders in this package rather than exposed as the
+// canonical Type values (those are registered with Registry.RegisterType).
+const (
+	ttlvTypeStructure   = Type(0x01)
+	ttlvTypeInteger     = Type(0x02)
+	ttlvTypeLongInteger = Type(0x03)
+	ttlvTypeBigInteger  = Type(0x04)
+	ttlvTypeEnumeration = Type(0x05)
+	ttlvTypeBoolean     = Type(0x06)
+	ttlvTypeTextString  = Type(0x07)
+	ttlvTypeByteString  = Type(0x08)
+	ttlvTypeDateTime    = Type(0x09)
+	ttlvTypeInterval    = Type(0x0A)
+)
+
+// ttlvNode is a single parsed TTLV value. It's the intermediate form shared
+// by the JSON and XML Profile codecs: both walk this tree instead of parsing
+// the wire bytes, or each other's output, directly.
+type ttlvNode struct {
+	tag   Tag
+	typ   Type
+	value interface{} // []ttlvNode for ttlvTypeStructure, else the decoded Go value
+}
+
+// decodeNode parses the single TTLV value at the start of b and returns it
+// along with whatever bytes in b follow it.
+func decodeNode(b []byte) (ttlvNode, []byte, error) {
+	if len(b) < headerSize {
+		return ttlvNode{}, nil, merry.New("ttlv: truncated header")
+	}
+
+	tag := Tag(uint32(b[0])<<16 | uint32(b[1])<<8 | uint32(b[2]))
+	typ := Type(b[3])
+	length := int(binary.BigEndian.Uint32(b[4:8]))
+
+	if length < 0 || len(b) < headerSize+length {
+		return ttlvNode{}, nil, merry.New("ttlv: truncated value")
+	}
+
+	val := b[headerSize : headerSize+length]
+	rest := b[headerSize+length:]
+
+	switch typ {
+	case ttlvTypeStructure:
+		var children []ttlvNode
+
+		remaining := val
+		for len(remaining) > 0 {
+			child, r, err := decodeNode(remaining)
+			if err != nil {
+				return ttlvNode{}, nil, err
+			}
+
+			children = append(children, child)
+			remaining = r
+		}
+
+		return ttlvNode{tag, typ, children}, rest, nil
+	case ttlvTypeInteger, ttlvTypeEnumeration, ttlvTypeInterval:
+		if len(val) < 4 {
+			return ttlvNode{}, nil, merry.New("ttlv: short integer value")
+		}
+
+		return ttlvNode{tag, typ, int32(binary.BigEndian.Uint32(val))}, rest, nil
+	case ttlvTypeLongInteger:
+		if len(val) < 8 {
+			return ttlvNode{}, nil, merry.New("ttlv: short long integer value")
+		}
+
+		return ttlvNode{tag, typ, int64(binary.BigEndian.Uint64(val))}, rest, nil
+	case ttlvTypeBigInteger:
+		return ttlvNode{tag, typ, append([]byte(nil), val...)}, rest, nil
+	case ttlvTypeBoolean:
+		if len(val) < 8 {
+			return ttlvNode{}, nil, merry.New("ttlv: short boolean value")
+		}
+
+		return ttlvNode{tag, typ, binary.BigEndian.Uint64(val) != 0}, rest, nil
+	case ttlvTypeTextString:
+		return ttlvNode{tag, typ, string(val)}, rest, nil
+	case ttlvTypeByteString:
+		return ttlvNode{tag, typ, append([]byte(nil), val...)}, rest, nil
+	case ttlvTypeDateTime:
+		if len(val) < 8 {
+			return ttlvNode{}, nil, merry.New("ttlv: short date-time value")
+		}
+
+		return ttlvNode{tag, typ, time.Unix(int64(binary.BigEndian.Uint64(val)), 0).UTC()}, rest, nil
+	default:
+		return ttlvNode{}, nil, merry.Errorf("ttlv: unsupported type %#x", byte(typ))
+	}
+}
+
+// encodeNode wraps tag, typ, and value (already in wire format) as a single
+// TTLV-encoded value.
+func encodeNode(tag Tag, typ Type, value []byte) TTLV {
+	buf := make([]byte, headerSize+len(value))
+	buf[0], buf[1], buf[2] = byte(tag>>16), byte(tag>>8), byte(tag)
+	buf[3] = byte(typ)
+	binary.BigEndian.PutUint32(buf[4:8], uint32(len(value)))
+	copy(buf[headerSize:], value)
+
+	return TTLV(buf)
+}
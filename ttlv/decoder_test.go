@@ -0,0 +1,59 @@
+package ttlv_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/gsealy/kmip-go/ttlv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_Next(t *testing.T) {
+	// tag 0x420069, type 0x02 (Integer), length 4, value 0x00000001
+	msg := []byte{0x42, 0x00, 0x69, 0x02, 0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x01}
+
+	d := ttlv.NewDecoder(bytes.NewReader(msg), 0)
+
+	got, err := d.Next()
+	require.NoError(t, err)
+	assert.Equal(t, ttlv.TTLV(msg), got)
+
+	_, err = d.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestDecoder_Next_MultipleMessages(t *testing.T) {
+	msg1 := []byte{0x42, 0x00, 0x69, 0x09, 0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x01}
+	msg2 := []byte{0x42, 0x00, 0x6a, 0x09, 0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x02}
+
+	d := ttlv.NewDecoder(bytes.NewReader(append(append([]byte{}, msg1...), msg2...)), 0)
+
+	got1, err := d.Next()
+	require.NoError(t, err)
+	assert.Equal(t, ttlv.TTLV(msg1), got1)
+
+	got2, err := d.Next()
+	require.NoError(t, err)
+	assert.Equal(t, ttlv.TTLV(msg2), got2)
+}
+
+func TestDecoder_Next_ExceedsMaxSize(t *testing.T) {
+	msg := []byte{0x42, 0x00, 0x69, 0x09, 0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x01}
+
+	d := ttlv.NewDecoder(bytes.NewReader(msg), 8)
+
+	_, err := d.Next()
+	assert.Error(t, err)
+}
+
+func TestDecoder_Next_TruncatedValue(t *testing.T) {
+	// declares a length of 4 but only provides 2 bytes of value
+	msg := []byte{0x42, 0x00, 0x69, 0x09, 0x00, 0x00, 0x00, 0x04, 0x00, 0x00}
+
+	d := ttlv.NewDecoder(bytes.NewReader(msg), 0)
+
+	_, err := d.Next()
+	assert.Error(t, err)
+}
@@ -0,0 +1,23 @@
+package ttlv_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gsealy/kmip-go/ttlv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoder_Encode(t *testing.T) {
+	msg := ttlv.TTLV([]byte{0x42, 0x00, 0x69, 0x02, 0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x01})
+
+	var buf bytes.Buffer
+	enc := ttlv.NewEncoder(&buf)
+
+	require.NoError(t, enc.Encode(msg))
+	assert.Equal(t, []byte(msg), buf.Bytes())
+
+	require.NoError(t, enc.Encode(msg))
+	assert.Equal(t, append(append([]byte{}, msg...), msg...), buf.Bytes())
+}
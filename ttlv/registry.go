@@ -1,17 +1,20 @@
 package ttlv
 
 import (
+	"sort"
+	"sync"
+
 	"github.com/ansel1/merry"
 	"github.com/gsealy/kmip-go/internal/kmiputil"
-	"sort"
 )
 
 // DefaultRegistry holds the default mappings of types, tags, enums, and bitmasks
 // to canonical names and normalized names from the KMIP spec.  It is pre-populated with the 1.4 spec's
 // values.  It can be replaced, or additional values can be registered with it.
 //
-// It is not currently concurrent-safe, so replace or configure it early in your
-// program.
+// It is safe to register additional values concurrently with lookups, so embedders
+// may register vendor extension tags, types, or enums at any point during the
+// program's lifetime, not just during init().
 var DefaultRegistry Registry
 
 // nolint:gochecknoinits
@@ -39,17 +42,18 @@ func NormalizeName(s string) string {
 // These mappings are also used to pretty print KMIP values, and to marshal
 // and unmarshal enum and bitmask values to golang string values.
 //
-// Enum currently uses plain maps, so it is not thread safe to register new values
-// concurrently.  You should register all values at the start of your program before
-// using this package concurrently.
-//
 // Enums are used in the KMIP spec for two purposes: for defining the possible values
 // for values encoded as the KMIP Enumeration type, and for bitmask values.  Bitmask
 // values are encoded as Integers, but are really enum values bitwise-OR'd together.
 //
 // Enums are registered with a Registry.  The code to register enums is typically
 // generated by the kmipgen tool.
+//
+// Enum is safe for concurrent use.  A mutex guards all the maps below, so
+// RegisterValue may be called concurrently with, or after, any of the lookup
+// methods.
 type Enum struct {
+	mu                    sync.RWMutex
 	valuesToName          map[uint32]string
 	valuesToCanonicalName map[uint32]string
 	nameToValue           map[string]uint32
@@ -72,6 +76,10 @@ func NewBitmask() Enum {
 // in the KMIP spec.
 func (e *Enum) RegisterValue(v uint32, name string) {
 	nn := NormalizeName(name)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	if e.valuesToName == nil {
 		e.valuesToName = map[uint32]string{}
 		e.nameToValue = map[string]uint32{}
@@ -88,6 +96,8 @@ func (e *Enum) Name(v uint32) (string, bool) {
 	if e == nil {
 		return "", false
 	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
 	name, ok := e.valuesToName[v]
 	return name, ok
 }
@@ -96,6 +106,8 @@ func (e *Enum) CanonicalName(v uint32) (string, bool) {
 	if e == nil {
 		return "", false
 	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
 	name, ok := e.valuesToCanonicalName[v]
 	return name, ok
 }
@@ -104,6 +116,8 @@ func (e *Enum) Value(name string) (uint32, bool) {
 	if e == nil {
 		return 0, false
 	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
 	v, ok := e.nameToValue[name]
 	if !ok {
 		v, ok = e.canonicalNamesToValue[name]
@@ -112,10 +126,12 @@ func (e *Enum) Value(name string) (uint32, bool) {
 }
 
 func (e *Enum) Values() []uint32 {
+	e.mu.RLock()
 	values := make([]uint32, 0, len(e.valuesToName))
 	for v := range e.valuesToName {
 		values = append(values, v)
 	}
+	e.mu.RUnlock()
 	// Always list them in order of value so output is stable.
 	sort.Sort(uint32Slice(values))
 	return values
@@ -125,16 +141,65 @@ func (e *Enum) Bitmask() bool {
 	if e == nil {
 		return false
 	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
 	return e.bitMask
 }
 
 // Registry holds all the known tags, types, enums and bitmaps declared in
 // a KMIP spec.  It's used throughout the package to map values their canonical
 // and normalized names.
+//
+// Registry is safe for concurrent use.  Registration methods may be called
+// at any time, including after the registry has started serving lookups, so
+// callers don't have to serialize all registration into init().
 type Registry struct {
-	enums map[Tag]EnumMap
-	tags  Enum
-	types Enum
+	mu       sync.RWMutex
+	enums    map[Tag]EnumMap
+	tags     Enum
+	types    Enum
+	versions map[protocolVersion]*Registry
+}
+
+// protocolVersion identifies a KMIP protocol version (major.minor) for the
+// purposes of registry overlays registered with RegisterVersion.
+type protocolVersion struct {
+	major uint8
+	minor uint8
+}
+
+// RegisterVersion associates a protocol version with an overlay Registry holding
+// the tags, types, and enums specific to that version of the KMIP spec (for
+// example, kmip20.Registry, built from the KMIP 2.x spec). Once registered, the
+// overlay can be retrieved with VersionOverlay, keyed off the ProtocolVersion
+// of an incoming or outgoing message, so a single process can serve clients on
+// different KMIP protocol versions.
+//
+// The plain lookup methods on Registry (EnumForTag, FormatEnum, ParseTag, etc.)
+// are version-agnostic and never consult the overlays registered here. Callers
+// that have a ProtocolVersion in scope, such as the marshaler/unmarshaler, use
+// the *Version variants below (EnumForTagVersion, FormatEnumVersion, etc.)
+// instead, which check the overlay registered for that version first and fall
+// back to r itself for anything the overlay doesn't override.
+func (r *Registry) RegisterVersion(major, minor uint8, overlay *Registry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.versions == nil {
+		r.versions = map[protocolVersion]*Registry{}
+	}
+	r.versions[protocolVersion{major, minor}] = overlay
+}
+
+// VersionOverlay returns the Registry registered for the given protocol version
+// with RegisterVersion, and true if one was found.
+func (r *Registry) VersionOverlay(major, minor uint8) (*Registry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.versions == nil {
+		return nil, false
+	}
+	overlay, ok := r.versions[protocolVersion{major, minor}]
+	return overlay, ok
 }
 
 func (r *Registry) RegisterType(t Type, name string) {
@@ -146,6 +211,8 @@ func (r *Registry) RegisterTag(t Tag, name string) {
 }
 
 func (r *Registry) RegisterEnum(t Tag, def EnumMap) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	if r.enums == nil {
 		r.enums = map[Tag]EnumMap{}
 	}
@@ -155,6 +222,8 @@ func (r *Registry) RegisterEnum(t Tag, def EnumMap) {
 // EnumForTag returns the enum map registered for a tag.  Returns
 // nil if no map is registered for this tag.
 func (r *Registry) EnumForTag(t Tag) EnumMap {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	if r.enums == nil {
 		return nil
 	}
@@ -0,0 +1,75 @@
+package ttlv_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gsealy/kmip-go/ttlv"
+)
+
+// TestRegistry_ConcurrentRegisterAndLookup exercises RegisterValue running
+// concurrently with Name/Value/Values, the scenario the mutex added to Enum
+// and Registry exists for. Run with -race: without the locking, this
+// reliably trips the race detector.
+func TestRegistry_ConcurrentRegisterAndLookup(t *testing.T) {
+	reg := &ttlv.Registry{}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		i := i
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			reg.RegisterTag(ttlv.Tag(i), "Tag")
+		}()
+	}
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			reg.Tags().Name(1)
+			reg.Tags().Value("Tag")
+			reg.Tags().Values()
+		}()
+	}
+
+	wg.Wait()
+}
+
+// TestEnum_ConcurrentRegisterAndLookup is the Enum-level counterpart: the
+// same concurrent access pattern, but directly against a shared Enum rather
+// than through Registry.
+func TestEnum_ConcurrentRegisterAndLookup(t *testing.T) {
+	e := ttlv.NewEnum()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		i := i
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			e.RegisterValue(uint32(i), "Value")
+		}()
+	}
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			e.Name(1)
+			e.CanonicalName(1)
+			e.Value("Value")
+			e.Values()
+			e.Bitmask()
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,102 @@
+package ttlv
+
+// EnumForTagVersion is the version-aware counterpart to EnumForTag: it returns
+// the enum map registered for t in the overlay registered for major.minor (see
+// RegisterVersion), falling back to r's own enum map if the overlay has none
+// registered for t, or if no overlay is registered for that version at all.
+func (r *Registry) EnumForTagVersion(major, minor uint8, t Tag) EnumMap {
+	if overlay, ok := r.VersionOverlay(major, minor); ok {
+		if e := overlay.EnumForTag(t); e != nil {
+			return e
+		}
+	}
+
+	return r.EnumForTag(t)
+}
+
+// IsBitmaskVersion is the version-aware counterpart to IsBitmask.
+func (r *Registry) IsBitmaskVersion(major, minor uint8, t Tag) bool {
+	if e := r.EnumForTagVersion(major, minor, t); e != nil {
+		return e.Bitmask()
+	}
+
+	return false
+}
+
+// IsEnumVersion is the version-aware counterpart to IsEnum.
+func (r *Registry) IsEnumVersion(major, minor uint8, t Tag) bool {
+	if e := r.EnumForTagVersion(major, minor, t); e != nil {
+		return !e.Bitmask()
+	}
+
+	return false
+}
+
+// FormatEnumVersion is the version-aware counterpart to FormatEnum.
+func (r *Registry) FormatEnumVersion(major, minor uint8, t Tag, v uint32) string {
+	return FormatEnum(v, r.EnumForTagVersion(major, minor, t))
+}
+
+// FormatIntVersion is the version-aware counterpart to FormatInt.
+func (r *Registry) FormatIntVersion(major, minor uint8, t Tag, v int32) string {
+	return FormatInt(v, r.EnumForTagVersion(major, minor, t))
+}
+
+// tagsVersion returns the tag Enum to use for major.minor: the overlay's, if
+// one is registered and has any tags of its own, otherwise r's.
+func (r *Registry) tagsVersion(major, minor uint8) *Enum {
+	if overlay, ok := r.VersionOverlay(major, minor); ok {
+		if len(overlay.tags.Values()) > 0 {
+			return &overlay.tags
+		}
+	}
+
+	return &r.tags
+}
+
+// typesVersion returns the type Enum to use for major.minor: the overlay's, if
+// one is registered and has any types of its own, otherwise r's.
+func (r *Registry) typesVersion(major, minor uint8) *Enum {
+	if overlay, ok := r.VersionOverlay(major, minor); ok {
+		if len(overlay.types.Values()) > 0 {
+			return &overlay.types
+		}
+	}
+
+	return &r.types
+}
+
+// FormatTagVersion is the version-aware counterpart to FormatTag.
+func (r *Registry) FormatTagVersion(major, minor uint8, t Tag) string {
+	return FormatTag(uint32(t), r.tagsVersion(major, minor))
+}
+
+// FormatTagCanonicalVersion is the version-aware counterpart to FormatTagCanonical.
+func (r *Registry) FormatTagCanonicalVersion(major, minor uint8, t Tag) string {
+	return FormatTagCanonical(uint32(t), r.tagsVersion(major, minor))
+}
+
+// FormatTypeVersion is the version-aware counterpart to FormatType.
+func (r *Registry) FormatTypeVersion(major, minor uint8, t Type) string {
+	return FormatType(byte(t), r.typesVersion(major, minor))
+}
+
+// ParseEnumVersion is the version-aware counterpart to ParseEnum.
+func (r *Registry) ParseEnumVersion(major, minor uint8, t Tag, s string) (uint32, error) {
+	return ParseEnum(s, r.EnumForTagVersion(major, minor, t))
+}
+
+// ParseIntVersion is the version-aware counterpart to ParseInt.
+func (r *Registry) ParseIntVersion(major, minor uint8, t Tag, s string) (int32, error) {
+	return ParseInt(s, r.EnumForTagVersion(major, minor, t))
+}
+
+// ParseTagVersion is the version-aware counterpart to ParseTag.
+func (r *Registry) ParseTagVersion(major, minor uint8, s string) (Tag, error) {
+	return ParseTag(s, r.tagsVersion(major, minor))
+}
+
+// ParseTypeVersion is the version-aware counterpart to ParseType.
+func (r *Registry) ParseTypeVersion(major, minor uint8, s string) (Type, error) {
+	return ParseType(s, r.typesVersion(major, minor))
+}
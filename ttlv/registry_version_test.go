@@ -0,0 +1,62 @@
+package ttlv_test
+
+import (
+	"testing"
+
+	"github.com/gsealy/kmip-go/ttlv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_RegisterVersion(t *testing.T) {
+	var reg ttlv.Registry
+
+	_, ok := reg.VersionOverlay(2, 0)
+	assert.False(t, ok)
+
+	overlay := &ttlv.Registry{}
+	overlay.RegisterTag(0x54000a, "My Vendor Tag")
+
+	reg.RegisterVersion(2, 0, overlay)
+
+	got, ok := reg.VersionOverlay(2, 0)
+	require.True(t, ok)
+	assert.Same(t, overlay, got)
+
+	_, ok = reg.VersionOverlay(1, 4)
+	assert.False(t, ok)
+}
+
+func TestRegistry_EnumForTagVersion(t *testing.T) {
+	var reg ttlv.Registry
+
+	base := ttlv.NewEnum()
+	base.RegisterValue(1, "Base Only")
+	reg.RegisterEnum(0x54000b, &base)
+
+	overlay := &ttlv.Registry{}
+	overlayEnum := ttlv.NewEnum()
+	overlayEnum.RegisterValue(1, "Overlay Wins")
+	overlay.RegisterEnum(0x54000b, &overlayEnum)
+
+	reg.RegisterVersion(2, 0, overlay)
+
+	// the 2.0 overlay overrides the base enum registered for this tag.
+	name, ok := reg.EnumForTagVersion(2, 0, 0x54000b).Name(1)
+	require.True(t, ok)
+	assert.Equal(t, "OverlayWins", name)
+
+	// a version with no overlay registered falls back to the base registry.
+	name, ok = reg.EnumForTagVersion(1, 4, 0x54000b).Name(1)
+	require.True(t, ok)
+	assert.Equal(t, "BaseOnly", name)
+
+	// a tag not registered in the overlay at all falls back to the base registry.
+	baseOnlyTag := ttlv.NewEnum()
+	baseOnlyTag.RegisterValue(2, "Base Only Tag")
+	reg.RegisterEnum(0x54000c, &baseOnlyTag)
+
+	name, ok = reg.EnumForTagVersion(2, 0, 0x54000c).Name(2)
+	require.True(t, ok)
+	assert.Equal(t, "BaseOnlyTag", name)
+}
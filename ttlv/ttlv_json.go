@@ -0,0 +1,298 @@
+package ttlv
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ansel1/merry"
+)
+
+// jsonTTLV is the wire shape of a single TTLV value in the KMIP JSON Profile
+// (spec 5.4.1.1): a tag name (or "0x"-hex fallback), a type name, and a value
+// whose JSON shape depends on type.
+type jsonTTLV struct {
+	Tag   string          `json:"tag"`
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// MarshalJSON encodes t per the KMIP JSON Profile, resolving tag, type, and
+// enum names against DefaultRegistry for protocol version 1.4. Use
+// DefaultRegistry.MarshalJSONVersion directly to encode against a different
+// negotiated version, such as one with a kmip20-style overlay registered.
+func (t TTLV) MarshalJSON() ([]byte, error) {
+	return DefaultRegistry.MarshalJSONVersion(1, 4, t)
+}
+
+// UnmarshalJSON decodes data per the KMIP JSON Profile into t, resolving
+// names against DefaultRegistry for protocol version 1.4. See MarshalJSON.
+func (t *TTLV) UnmarshalJSON(data []byte) error {
+	return DefaultRegistry.UnmarshalJSONVersion(1, 4, data, t)
+}
+
+// MarshalJSONVersion encodes t per the KMIP JSON Profile (spec 5.4.1.1):
+// integers and intervals as JSON numbers, long and big integers as "0x"-hex
+// strings, enums as their normalized name (or "0x"-hex if unregistered),
+// bitmasks as an array of such names, byte strings as hex, and date-times as
+// RFC3339. Tag, type, and enum names are resolved against the overlay
+// registered for major.minor (see RegisterVersion), falling back to r for
+// anything the overlay doesn't cover.
+func (r *Registry) MarshalJSONVersion(major, minor uint8, t TTLV) ([]byte, error) {
+	node, rest, err := decodeNode(t)
+	if err != nil {
+		return nil, merry.Prepend(err, "ttlv: decoding for JSON")
+	}
+
+	if len(rest) != 0 {
+		return nil, merry.New("ttlv: trailing bytes after value")
+	}
+
+	out, err := r.encodeJSONNode(major, minor, node)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(out)
+}
+
+// UnmarshalJSONVersion is the inverse of MarshalJSONVersion: it decodes data
+// into t, resolving tag, type, and enum names against the overlay registered
+// for major.minor.
+func (r *Registry) UnmarshalJSONVersion(major, minor uint8, data []byte, t *TTLV) error {
+	var in jsonTTLV
+	if err := json.Unmarshal(data, &in); err != nil {
+		return merry.Prepend(err, "ttlv: decoding JSON")
+	}
+
+	b, err := r.decodeJSONNode(major, minor, in)
+	if err != nil {
+		return err
+	}
+
+	*t = b
+
+	return nil
+}
+
+func (r *Registry) encodeJSONNode(major, minor uint8, n ttlvNode) (jsonTTLV, error) {
+	out := jsonTTLV{
+		Tag:  r.FormatTagVersion(major, minor, n.tag),
+		Type: r.FormatTypeVersion(major, minor, n.typ),
+	}
+
+	var v interface{}
+
+	switch n.typ {
+	case ttlvTypeStructure:
+		children := n.value.([]ttlvNode)
+		encoded := make([]jsonTTLV, len(children))
+
+		for i, c := range children {
+			enc, err := r.encodeJSONNode(major, minor, c)
+			if err != nil {
+				return jsonTTLV{}, err
+			}
+
+			encoded[i] = enc
+		}
+
+		v = encoded
+	case ttlvTypeInteger:
+		iv := n.value.(int32)
+		if r.IsBitmaskVersion(major, minor, n.tag) {
+			v = FormatBitmaskJSON(uint32(iv), r.EnumForTagVersion(major, minor, n.tag))
+		} else {
+			v = iv
+		}
+	case ttlvTypeEnumeration:
+		v = r.FormatEnumVersion(major, minor, n.tag, uint32(n.value.(int32)))
+	case ttlvTypeInterval:
+		v = n.value.(int32)
+	case ttlvTypeLongInteger:
+		v = fmt.Sprintf("0x%016X", uint64(n.value.(int64)))
+	case ttlvTypeBigInteger:
+		v = "0x" + hex.EncodeToString(n.value.([]byte))
+	case ttlvTypeBoolean:
+		v = n.value.(bool)
+	case ttlvTypeTextString:
+		v = n.value.(string)
+	case ttlvTypeByteString:
+		v = hex.EncodeToString(n.value.([]byte))
+	case ttlvTypeDateTime:
+		v = n.value.(time.Time).UTC().Format(time.RFC3339)
+	default:
+		return jsonTTLV{}, merry.Errorf("ttlv: unsupported type %#x for JSON encoding", byte(n.typ))
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return jsonTTLV{}, merry.Prepend(err, "ttlv: encoding value")
+	}
+
+	out.Value = b
+
+	return out, nil
+}
+
+func (r *Registry) decodeJSONNode(major, minor uint8, in jsonTTLV) (TTLV, error) {
+	tag, err := r.ParseTagVersion(major, minor, in.Tag)
+	if err != nil {
+		return nil, merry.Prependf(err, "ttlv: tag %q", in.Tag)
+	}
+
+	typ, err := r.ParseTypeVersion(major, minor, in.Type)
+	if err != nil {
+		return nil, merry.Prependf(err, "ttlv: type %q", in.Type)
+	}
+
+	var value []byte
+
+	switch typ {
+	case ttlvTypeStructure:
+		var children []jsonTTLV
+		if err := json.Unmarshal(in.Value, &children); err != nil {
+			return nil, merry.Prepend(err, "ttlv: decoding structure value")
+		}
+
+		for _, c := range children {
+			cb, err := r.decodeJSONNode(major, minor, c)
+			if err != nil {
+				return nil, err
+			}
+
+			value = append(value, cb...)
+		}
+	case ttlvTypeInteger:
+		if r.IsBitmaskVersion(major, minor, tag) {
+			var names []string
+			if err := json.Unmarshal(in.Value, &names); err != nil {
+				return nil, merry.Prepend(err, "ttlv: decoding bitmask value")
+			}
+
+			bm, err := ParseBitmaskJSON(names, r.EnumForTagVersion(major, minor, tag))
+			if err != nil {
+				return nil, err
+			}
+
+			value = make([]byte, 4)
+			binary.BigEndian.PutUint32(value, bm)
+		} else {
+			var iv int32
+			if err := json.Unmarshal(in.Value, &iv); err != nil {
+				return nil, merry.Prepend(err, "ttlv: decoding integer value")
+			}
+
+			value = make([]byte, 4)
+			binary.BigEndian.PutUint32(value, uint32(iv))
+		}
+	case ttlvTypeEnumeration:
+		var s string
+		if err := json.Unmarshal(in.Value, &s); err != nil {
+			return nil, merry.Prepend(err, "ttlv: decoding enumeration value")
+		}
+
+		ev, err := r.ParseEnumVersion(major, minor, tag, s)
+		if err != nil {
+			return nil, err
+		}
+
+		value = make([]byte, 4)
+		binary.BigEndian.PutUint32(value, ev)
+	case ttlvTypeInterval:
+		var iv int32
+		if err := json.Unmarshal(in.Value, &iv); err != nil {
+			return nil, merry.Prepend(err, "ttlv: decoding interval value")
+		}
+
+		value = make([]byte, 4)
+		binary.BigEndian.PutUint32(value, uint32(iv))
+	case ttlvTypeLongInteger:
+		var s string
+		if err := json.Unmarshal(in.Value, &s); err != nil {
+			return nil, merry.Prepend(err, "ttlv: decoding long integer value")
+		}
+
+		lv, err := parseHexUint64(s)
+		if err != nil {
+			return nil, err
+		}
+
+		value = make([]byte, 8)
+		binary.BigEndian.PutUint64(value, lv)
+	case ttlvTypeBigInteger:
+		var s string
+		if err := json.Unmarshal(in.Value, &s); err != nil {
+			return nil, merry.Prepend(err, "ttlv: decoding big integer value")
+		}
+
+		if value, err = parseHexBytes(s); err != nil {
+			return nil, err
+		}
+	case ttlvTypeBoolean:
+		var b bool
+		if err := json.Unmarshal(in.Value, &b); err != nil {
+			return nil, merry.Prepend(err, "ttlv: decoding boolean value")
+		}
+
+		value = make([]byte, 8)
+		if b {
+			binary.BigEndian.PutUint64(value, 1)
+		}
+	case ttlvTypeTextString:
+		var s string
+		if err := json.Unmarshal(in.Value, &s); err != nil {
+			return nil, merry.Prepend(err, "ttlv: decoding text string value")
+		}
+
+		value = []byte(s)
+	case ttlvTypeByteString:
+		var s string
+		if err := json.Unmarshal(in.Value, &s); err != nil {
+			return nil, merry.Prepend(err, "ttlv: decoding byte string value")
+		}
+
+		if value, err = parseHexBytes(s); err != nil {
+			return nil, err
+		}
+	case ttlvTypeDateTime:
+		var s string
+		if err := json.Unmarshal(in.Value, &s); err != nil {
+			return nil, merry.Prepend(err, "ttlv: decoding date-time value")
+		}
+
+		tm, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return nil, merry.Prependf(err, "ttlv: date-time value %q", s)
+		}
+
+		value = make([]byte, 8)
+		binary.BigEndian.PutUint64(value, uint64(tm.Unix()))
+	default:
+		return nil, merry.Errorf("ttlv: unsupported type %q for JSON decoding", in.Type)
+	}
+
+	return encodeNode(tag, typ, value), nil
+}
+
+func parseHexUint64(s string) (uint64, error) {
+	v, err := strconv.ParseUint(strings.TrimPrefix(s, "0x"), 16, 64)
+	if err != nil {
+		return 0, merry.Prependf(err, "ttlv: hex value %q", s)
+	}
+
+	return v, nil
+}
+
+func parseHexBytes(s string) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, merry.Prependf(err, "ttlv: hex value %q", s)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,124 @@
+package ttlv_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/gsealy/kmip-go/ttlv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRegistry() *ttlv.Registry {
+	reg := &ttlv.Registry{}
+	ttlv.RegisterTypes(reg)
+
+	return reg
+}
+
+// buildTLV hand-encodes a single TTLV value: a 3-byte tag, a 1-byte type, a
+// 4-byte length, and the raw value bytes, with no padding, matching the wire
+// format Decoder/Encoder already use.
+func buildTLV(tag ttlv.Tag, typ byte, value []byte) []byte {
+	out := make([]byte, 8+len(value))
+	out[0], out[1], out[2] = byte(tag>>16), byte(tag>>8), byte(tag)
+	out[3] = typ
+	binary.BigEndian.PutUint32(out[4:8], uint32(len(value)))
+	copy(out[8:], value)
+
+	return out
+}
+
+func int32Bytes(v int32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(v))
+
+	return b
+}
+
+// buildScalar hand-encodes a single 4-byte-value TTLV (Integer or
+// Enumeration).
+func buildScalar(tag ttlv.Tag, typ byte, v int32) ttlv.TTLV {
+	return ttlv.TTLV(buildTLV(tag, typ, int32Bytes(v)))
+}
+
+func TestTTLV_JSON_RoundTrip(t *testing.T) {
+	reg := newTestRegistry()
+	reg.RegisterTag(ttlv.Tag(0x540001), "Struct")
+	reg.RegisterTag(ttlv.Tag(0x540002), "IntValue")
+	reg.RegisterTag(ttlv.Tag(0x540003), "StrValue")
+
+	intChild := buildTLV(ttlv.Tag(0x540002), 0x02, int32Bytes(24))
+	strChild := buildTLV(ttlv.Tag(0x540003), 0x07, []byte("hello"))
+	value := append(append([]byte{}, intChild...), strChild...)
+	orig := ttlv.TTLV(buildTLV(ttlv.Tag(0x540001), 0x01, value))
+
+	b, err := reg.MarshalJSONVersion(1, 4, orig)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"tag":"Struct","type":"Structure","value":[
+		{"tag":"IntValue","type":"Integer","value":24},
+		{"tag":"StrValue","type":"TextString","value":"hello"}
+	]}`, string(b))
+
+	var got ttlv.TTLV
+	require.NoError(t, reg.UnmarshalJSONVersion(1, 4, b, &got))
+	assert.Equal(t, orig, got)
+}
+
+func TestTTLV_JSON_Bitmask(t *testing.T) {
+	reg := newTestRegistry()
+	reg.RegisterTag(ttlv.Tag(0x540004), "CryptoUsageMask")
+
+	e := ttlv.NewBitmask()
+	e.RegisterValue(1, "Encrypt")
+	e.RegisterValue(2, "Decrypt")
+	reg.RegisterEnum(ttlv.Tag(0x540004), &e)
+
+	orig := buildScalar(ttlv.Tag(0x540004), 0x02, 3)
+
+	b, err := reg.MarshalJSONVersion(1, 4, orig)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"tag":"CryptoUsageMask","type":"Integer","value":["Encrypt","Decrypt"]}`, string(b))
+
+	var got ttlv.TTLV
+	require.NoError(t, reg.UnmarshalJSONVersion(1, 4, b, &got))
+	assert.Equal(t, orig, got)
+}
+
+func TestTTLV_JSON_Enumeration(t *testing.T) {
+	reg := newTestRegistry()
+	reg.RegisterTag(ttlv.Tag(0x540005), "State")
+
+	e := ttlv.NewEnum()
+	e.RegisterValue(1, "Active")
+	reg.RegisterEnum(ttlv.Tag(0x540005), &e)
+
+	orig := buildScalar(ttlv.Tag(0x540005), 0x05, 1)
+
+	b, err := reg.MarshalJSONVersion(1, 4, orig)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"tag":"State","type":"Enumeration","value":"Active"}`, string(b))
+
+	var got ttlv.TTLV
+	require.NoError(t, reg.UnmarshalJSONVersion(1, 4, b, &got))
+	assert.Equal(t, orig, got)
+}
+
+func TestTTLV_MarshalJSONVersion_UsesOverlay(t *testing.T) {
+	base := newTestRegistry()
+	base.RegisterTag(ttlv.Tag(0x540006), "BaseOnlyName")
+
+	overlay := &ttlv.Registry{}
+	overlay.RegisterTag(ttlv.Tag(0x540006), "OverlayName")
+	base.RegisterVersion(2, 0, overlay)
+
+	orig := buildScalar(ttlv.Tag(0x540006), 0x02, 7)
+
+	b14, err := base.MarshalJSONVersion(1, 4, orig)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"tag":"BaseOnlyName","type":"Integer","value":7}`, string(b14))
+
+	b20, err := base.MarshalJSONVersion(2, 0, orig)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"tag":"OverlayName","type":"Integer","value":7}`, string(b20))
+}
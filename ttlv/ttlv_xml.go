@@ -0,0 +1,295 @@
+package ttlv
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ansel1/merry"
+)
+
+// MarshalXML encodes t per the KMIP XML Profile (spec 5.5.1.1), resolving
+// tag, type, and enum names against DefaultRegistry for protocol version 1.4.
+// Use DefaultRegistry.MarshalXMLVersion directly to encode against a
+// different negotiated version.
+func (t TTLV) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return DefaultRegistry.MarshalXMLVersion(1, 4, t, e, start)
+}
+
+// UnmarshalXML decodes an element per the KMIP XML Profile into t, resolving
+// names against DefaultRegistry for protocol version 1.4. See MarshalXML.
+func (t *TTLV) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return DefaultRegistry.UnmarshalXMLVersion(1, 4, d, start, t)
+}
+
+// MarshalXMLVersion encodes t per the KMIP XML Profile: each TTLV value
+// becomes an element named for its tag, with a "type" attribute and either a
+// "value" attribute (scalar types) or nested elements (Structure). Values are
+// formatted the same way as MarshalJSONVersion's JSON values, just as
+// attribute strings rather than JSON literals. start is ignored, namespace
+// included: the element name is always the tag's own name, not whatever name
+// the caller's struct field would otherwise produce.
+func (r *Registry) MarshalXMLVersion(major, minor uint8, t TTLV, e *xml.Encoder, start xml.StartElement) error {
+	node, rest, err := decodeNode(t)
+	if err != nil {
+		return merry.Prepend(err, "ttlv: decoding for XML")
+	}
+
+	if len(rest) != 0 {
+		return merry.New("ttlv: trailing bytes after value")
+	}
+
+	return r.encodeXMLNode(major, minor, node, e)
+}
+
+// UnmarshalXMLVersion is the inverse of MarshalXMLVersion: it decodes the
+// element at start (and, for a Structure, its children) into t.
+func (r *Registry) UnmarshalXMLVersion(major, minor uint8, d *xml.Decoder, start xml.StartElement, t *TTLV) error {
+	b, err := r.decodeXMLElement(major, minor, d, start)
+	if err != nil {
+		return err
+	}
+
+	*t = b
+
+	return nil
+}
+
+func (r *Registry) encodeXMLNode(major, minor uint8, n ttlvNode, e *xml.Encoder) error {
+	start := xml.StartElement{
+		Name: xml.Name{Local: r.xmlTagNameVersion(major, minor, n.tag)},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "type"}, Value: r.FormatTypeVersion(major, minor, n.typ)}},
+	}
+
+	if n.typ == ttlvTypeStructure {
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+
+		for _, c := range n.value.([]ttlvNode) {
+			if err := r.encodeXMLNode(major, minor, c, e); err != nil {
+				return err
+			}
+		}
+
+		return e.EncodeToken(start.End())
+	}
+
+	val, err := r.xmlValueStringVersion(major, minor, n)
+	if err != nil {
+		return err
+	}
+
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "value"}, Value: val})
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+func (r *Registry) decodeXMLElement(major, minor uint8, d *xml.Decoder, start xml.StartElement) (TTLV, error) {
+	tag, err := r.ParseTagVersion(major, minor, strings.TrimPrefix(start.Name.Local, "_"))
+	if err != nil {
+		return nil, merry.Prependf(err, "ttlv: tag %q", start.Name.Local)
+	}
+
+	var typeAttr, valueAttr string
+
+	hasValue := false
+
+	for _, a := range start.Attr {
+		switch a.Name.Local {
+		case "type":
+			typeAttr = a.Value
+		case "value":
+			valueAttr = a.Value
+			hasValue = true
+		}
+	}
+
+	typ, err := r.ParseTypeVersion(major, minor, typeAttr)
+	if err != nil {
+		return nil, merry.Prependf(err, "ttlv: type %q", typeAttr)
+	}
+
+	if typ == ttlvTypeStructure {
+		var value []byte
+
+		for {
+			tok, err := d.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			switch tok := tok.(type) {
+			case xml.StartElement:
+				child, err := r.decodeXMLElement(major, minor, d, tok)
+				if err != nil {
+					return nil, err
+				}
+
+				value = append(value, child...)
+			case xml.EndElement:
+				return encodeNode(tag, typ, value), nil
+			}
+		}
+	}
+
+	if !hasValue {
+		return nil, merry.Errorf("ttlv: missing value attribute for %q", start.Name.Local)
+	}
+
+	// Leaf elements have no children to walk; consume through their own
+	// matching end element.
+	if err := d.Skip(); err != nil {
+		return nil, err
+	}
+
+	value, err := r.xmlParseValueVersion(major, minor, tag, typ, valueAttr)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeNode(tag, typ, value), nil
+}
+
+// xmlTagNameVersion returns the XML element local name for t: its registered
+// name if there is one, or its hex tag prefixed with "_" if not, since XML
+// names can't start with a digit the way the JSON Profile's "0x..." fallback
+// can.
+func (r *Registry) xmlTagNameVersion(major, minor uint8, t Tag) string {
+	name := r.FormatTagVersion(major, minor, t)
+	if strings.HasPrefix(name, "0x") {
+		return "_" + name
+	}
+
+	return name
+}
+
+func (r *Registry) xmlValueStringVersion(major, minor uint8, n ttlvNode) (string, error) {
+	switch n.typ {
+	case ttlvTypeInteger:
+		iv := n.value.(int32)
+		if r.IsBitmaskVersion(major, minor, n.tag) {
+			return strings.Join(FormatBitmaskJSON(uint32(iv), r.EnumForTagVersion(major, minor, n.tag)), " "), nil
+		}
+
+		return strconv.FormatInt(int64(iv), 10), nil
+	case ttlvTypeEnumeration:
+		return r.FormatEnumVersion(major, minor, n.tag, uint32(n.value.(int32))), nil
+	case ttlvTypeInterval:
+		return strconv.FormatInt(int64(n.value.(int32)), 10), nil
+	case ttlvTypeLongInteger:
+		return fmt.Sprintf("0x%016X", uint64(n.value.(int64))), nil
+	case ttlvTypeBigInteger:
+		return "0x" + hex.EncodeToString(n.value.([]byte)), nil
+	case ttlvTypeBoolean:
+		return strconv.FormatBool(n.value.(bool)), nil
+	case ttlvTypeTextString:
+		return n.value.(string), nil
+	case ttlvTypeByteString:
+		return hex.EncodeToString(n.value.([]byte)), nil
+	case ttlvTypeDateTime:
+		return n.value.(time.Time).UTC().Format(time.RFC3339), nil
+	default:
+		return "", merry.Errorf("ttlv: unsupported type %#x for XML encoding", byte(n.typ))
+	}
+}
+
+func (r *Registry) xmlParseValueVersion(major, minor uint8, tag Tag, typ Type, s string) ([]byte, error) {
+	switch typ {
+	case ttlvTypeInteger:
+		if r.IsBitmaskVersion(major, minor, tag) {
+			var names []string
+			if s != "" {
+				names = strings.Fields(s)
+			}
+
+			bm, err := ParseBitmaskJSON(names, r.EnumForTagVersion(major, minor, tag))
+			if err != nil {
+				return nil, err
+			}
+
+			value := make([]byte, 4)
+			binary.BigEndian.PutUint32(value, bm)
+
+			return value, nil
+		}
+
+		iv, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return nil, merry.Prependf(err, "ttlv: integer value %q", s)
+		}
+
+		value := make([]byte, 4)
+		binary.BigEndian.PutUint32(value, uint32(int32(iv)))
+
+		return value, nil
+	case ttlvTypeEnumeration:
+		ev, err := r.ParseEnumVersion(major, minor, tag, s)
+		if err != nil {
+			return nil, err
+		}
+
+		value := make([]byte, 4)
+		binary.BigEndian.PutUint32(value, ev)
+
+		return value, nil
+	case ttlvTypeInterval:
+		iv, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return nil, merry.Prependf(err, "ttlv: interval value %q", s)
+		}
+
+		value := make([]byte, 4)
+		binary.BigEndian.PutUint32(value, uint32(int32(iv)))
+
+		return value, nil
+	case ttlvTypeLongInteger:
+		lv, err := parseHexUint64(s)
+		if err != nil {
+			return nil, err
+		}
+
+		value := make([]byte, 8)
+		binary.BigEndian.PutUint64(value, lv)
+
+		return value, nil
+	case ttlvTypeBigInteger:
+		return parseHexBytes(s)
+	case ttlvTypeBoolean:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, merry.Prependf(err, "ttlv: boolean value %q", s)
+		}
+
+		value := make([]byte, 8)
+		if b {
+			binary.BigEndian.PutUint64(value, 1)
+		}
+
+		return value, nil
+	case ttlvTypeTextString:
+		return []byte(s), nil
+	case ttlvTypeByteString:
+		return parseHexBytes(s)
+	case ttlvTypeDateTime:
+		tm, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return nil, merry.Prependf(err, "ttlv: date-time value %q", s)
+		}
+
+		value := make([]byte, 8)
+		binary.BigEndian.PutUint64(value, uint64(tm.Unix()))
+
+		return value, nil
+	default:
+		return nil, merry.Errorf("ttlv: unsupported type %#x for XML decoding", byte(typ))
+	}
+}
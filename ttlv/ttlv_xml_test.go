@@ -0,0 +1,63 @@
+package ttlv_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/gsealy/kmip-go/ttlv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTTLV_XML_RoundTrip(t *testing.T) {
+	reg := newTestRegistry()
+	reg.RegisterTag(ttlv.Tag(0x540001), "Struct")
+	reg.RegisterTag(ttlv.Tag(0x540002), "IntValue")
+	reg.RegisterTag(ttlv.Tag(0x540003), "StrValue")
+
+	intChild := buildTLV(ttlv.Tag(0x540002), 0x02, int32Bytes(24))
+	strChild := buildTLV(ttlv.Tag(0x540003), 0x07, []byte("hello"))
+	value := append(append([]byte{}, intChild...), strChild...)
+	orig := ttlv.TTLV(buildTLV(ttlv.Tag(0x540001), 0x01, value))
+
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+	require.NoError(t, reg.MarshalXMLVersion(1, 4, orig, e, xml.StartElement{}))
+	require.NoError(t, e.Flush())
+
+	assert.Equal(t,
+		`<Struct type="Structure"><IntValue type="Integer" value="24"></IntValue><StrValue type="TextString" value="hello"></StrValue></Struct>`,
+		buf.String())
+
+	d := xml.NewDecoder(&buf)
+	tok, err := d.Token()
+	require.NoError(t, err)
+	start := tok.(xml.StartElement)
+
+	var got ttlv.TTLV
+	require.NoError(t, reg.UnmarshalXMLVersion(1, 4, d, start, &got))
+	assert.Equal(t, orig, got)
+}
+
+func TestTTLV_XML_UnregisteredTag(t *testing.T) {
+	reg := newTestRegistry()
+
+	orig := buildScalar(ttlv.Tag(0x540099), 0x02, 5)
+
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+	require.NoError(t, reg.MarshalXMLVersion(1, 4, orig, e, xml.StartElement{}))
+	require.NoError(t, e.Flush())
+
+	assert.Equal(t, `<_0x540099 type="Integer" value="5"></_0x540099>`, buf.String())
+
+	d := xml.NewDecoder(&buf)
+	tok, err := d.Token()
+	require.NoError(t, err)
+	start := tok.(xml.StartElement)
+
+	var got ttlv.TTLV
+	require.NoError(t, reg.UnmarshalXMLVersion(1, 4, d, start, &got))
+	assert.Equal(t, orig, got)
+}